@@ -0,0 +1,137 @@
+package apperror
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// FieldViolation describes a single invalid field in a request, as reported
+// by a google.rpc.BadRequest error detail. Rule and Value are this
+// package's own extensions for typed validation (see AppError.Violations,
+// WithViolation); they have no equivalent in the google.rpc.BadRequest
+// proto, so they don't survive a round trip through GRPCStatus/
+// FromGRPCStatus.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Rule        string `json:"rule,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+}
+
+// QuotaViolation describes a single quota dimension that was exceeded, as
+// reported by a google.rpc.QuotaFailure error detail.
+type QuotaViolation struct {
+	Subject     string
+	Description string
+}
+
+// HelpLink points the caller at further documentation for an error, as
+// reported by a google.rpc.Help error detail.
+type HelpLink struct {
+	Description string
+	URL         string
+}
+
+// WithBadRequest attaches a google.rpc.BadRequest detail listing the
+// request fields that failed validation.
+func (e *AppError) WithBadRequest(violations ...FieldViolation) *AppError {
+	copyErr := *e
+	fv := make([]*errdetails.BadRequest_FieldViolation, len(violations))
+	for i, v := range violations {
+		fv[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+	copyErr.badRequest = &errdetails.BadRequest{FieldViolations: fv}
+	return &copyErr
+}
+
+// WithRetryInfo attaches a google.rpc.RetryInfo detail telling the caller
+// how long to wait before retrying the request.
+func (e *AppError) WithRetryInfo(delay time.Duration) *AppError {
+	copyErr := *e
+	copyErr.retryInfo = &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}
+	return &copyErr
+}
+
+// WithQuotaFailure attaches a google.rpc.QuotaFailure detail describing
+// which quotas were exceeded.
+func (e *AppError) WithQuotaFailure(violations ...QuotaViolation) *AppError {
+	copyErr := *e
+	qv := make([]*errdetails.QuotaFailure_Violation, len(violations))
+	for i, v := range violations {
+		qv[i] = &errdetails.QuotaFailure_Violation{
+			Subject:     v.Subject,
+			Description: v.Description,
+		}
+	}
+	copyErr.quotaFailure = &errdetails.QuotaFailure{Violations: qv}
+	return &copyErr
+}
+
+// WithResourceInfo attaches a google.rpc.ResourceInfo detail identifying
+// the resource the error relates to.
+func (e *AppError) WithResourceInfo(resourceType, name, owner, description string) *AppError {
+	copyErr := *e
+	copyErr.resourceInfo = &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: name,
+		Owner:        owner,
+		Description:  description,
+	}
+	return &copyErr
+}
+
+// WithHelp attaches a google.rpc.Help detail pointing the caller at further
+// documentation.
+func (e *AppError) WithHelp(links ...HelpLink) *AppError {
+	copyErr := *e
+	hl := make([]*errdetails.Help_Link, len(links))
+	for i, l := range links {
+		hl[i] = &errdetails.Help_Link{Description: l.Description, Url: l.URL}
+	}
+	copyErr.help = &errdetails.Help{Links: hl}
+	return &copyErr
+}
+
+// WithLocalizedMessage attaches a google.rpc.LocalizedMessage detail
+// carrying an end-user-safe message in the given locale.
+func (e *AppError) WithLocalizedMessage(locale, msg string) *AppError {
+	copyErr := *e
+	copyErr.localizedMessage = &errdetails.LocalizedMessage{Locale: locale, Message: msg}
+	return &copyErr
+}
+
+// BadRequest returns the error's BadRequest detail, or nil if none was set.
+func (e *AppError) BadRequest() *errdetails.BadRequest {
+	return e.badRequest
+}
+
+// RetryInfo returns the error's RetryInfo detail, or nil if none was set.
+func (e *AppError) RetryInfo() *errdetails.RetryInfo {
+	return e.retryInfo
+}
+
+// QuotaFailure returns the error's QuotaFailure detail, or nil if none was set.
+func (e *AppError) QuotaFailure() *errdetails.QuotaFailure {
+	return e.quotaFailure
+}
+
+// ResourceInfo returns the error's ResourceInfo detail, or nil if none was set.
+func (e *AppError) ResourceInfo() *errdetails.ResourceInfo {
+	return e.resourceInfo
+}
+
+// Help returns the error's Help detail, or nil if none was set.
+func (e *AppError) Help() *errdetails.Help {
+	return e.help
+}
+
+// LocalizedMessage returns the error's LocalizedMessage detail, or nil if
+// none was set.
+func (e *AppError) LocalizedMessage() *errdetails.LocalizedMessage {
+	return e.localizedMessage
+}
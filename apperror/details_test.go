@@ -0,0 +1,75 @@
+package apperror
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestAppError_DetailsRoundTripThroughGRPCStatus(t *testing.T) {
+	err := New("svc", "001", "invalid request").
+		WithGRPCCode(codes.InvalidArgument).
+		WithBadRequest(FieldViolation{Field: "email", Description: "must be set"}).
+		WithRetryInfo(2*time.Second).
+		WithQuotaFailure(QuotaViolation{Subject: "user:42", Description: "rate limit"}).
+		WithResourceInfo("document", "doc-1", "owner-1", "not accessible").
+		WithHelp(HelpLink{Description: "docs", URL: "https://example.com/docs"}).
+		WithLocalizedMessage("fr-FR", "requete invalide")
+
+	st := err.GRPCStatus()
+	got, ok := FromGRPCStatus(st).(*AppError)
+	if !ok {
+		t.Fatalf("FromGRPCStatus() = %T, want *AppError", FromGRPCStatus(st))
+	}
+
+	if br := got.BadRequest(); br == nil || len(br.GetFieldViolations()) != 1 || br.GetFieldViolations()[0].GetField() != "email" {
+		t.Fatalf("BadRequest() = %+v, want one field_violation for \"email\"", br)
+	}
+	if ri := got.RetryInfo(); ri == nil || ri.GetRetryDelay().AsDuration() != 2*time.Second {
+		t.Fatalf("RetryInfo() = %+v, want a 2s delay", ri)
+	}
+	if qf := got.QuotaFailure(); qf == nil || len(qf.GetViolations()) != 1 || qf.GetViolations()[0].GetSubject() != "user:42" {
+		t.Fatalf("QuotaFailure() = %+v, want one violation for \"user:42\"", qf)
+	}
+	if ri := got.ResourceInfo(); ri == nil || ri.GetResourceType() != "document" || ri.GetResourceName() != "doc-1" {
+		t.Fatalf("ResourceInfo() = %+v, want document/doc-1", ri)
+	}
+	if h := got.Help(); h == nil || len(h.GetLinks()) != 1 || h.GetLinks()[0].GetUrl() != "https://example.com/docs" {
+		t.Fatalf("Help() = %+v, want one link to example.com/docs", h)
+	}
+	if lm := got.LocalizedMessage(); lm == nil || lm.GetLocale() != "fr-FR" || lm.GetMessage() != "requete invalide" {
+		t.Fatalf("LocalizedMessage() = %+v, want fr-FR/\"requete invalide\"", lm)
+	}
+
+	// The typed Violations also rehydrate from the round-tripped BadRequest.
+	if len(got.Violations) != 1 || got.Violations[0].Field != "email" {
+		t.Fatalf("Violations = %+v, want one violation for \"email\"", got.Violations)
+	}
+}
+
+func TestAppError_WithBadRequestTakesPrecedenceOverViolations(t *testing.T) {
+	err := New("svc", "001", "invalid request").
+		WithGRPCCode(codes.InvalidArgument).
+		WithViolation("name", "required", "must be set").
+		WithBadRequest(FieldViolation{Field: "explicit", Description: "from WithBadRequest"})
+
+	st := err.GRPCStatus()
+	got := FromGRPCStatus(st).(*AppError)
+
+	if len(got.Violations) != 1 || got.Violations[0].Field != "explicit" {
+		t.Fatalf("Violations = %+v, want the explicit WithBadRequest detail to win over WithViolation", got.Violations)
+	}
+}
+
+func TestAppError_NoDetailsRoundTripsCleanly(t *testing.T) {
+	err := New("svc", "001", "plain error").WithGRPCCode(codes.Internal)
+
+	st := err.GRPCStatus()
+	got := FromGRPCStatus(st).(*AppError)
+
+	if got.BadRequest() != nil || got.RetryInfo() != nil || got.QuotaFailure() != nil ||
+		got.ResourceInfo() != nil || got.Help() != nil || got.LocalizedMessage() != nil {
+		t.Fatalf("got unexpected detail on a plain error: %+v", got)
+	}
+}
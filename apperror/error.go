@@ -5,6 +5,9 @@ package apperror
 
 import (
 	"fmt"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 // ErrorFields is a type for structured error fields.
@@ -14,13 +17,32 @@ type ErrorFields map[string]string
 // a unique code, a human-readable message, and additional structured fields.
 // It supports error wrapping and can be converted to a gRPC status.
 type AppError struct {
-	Service  string      `json:"service,omitempty"` // example: user-service
-	Code     string      `json:"code,omitempty"`    // example: 001
-	Message  string      `json:"message"`           // human readable message
-	Fields   ErrorFields `json:"fields,omitempty"`  // additional fields, key-value pairs
-	cause    error       // wrapped error
-	grpcCode uint32      // used in grpc.go
-	traceID  string      // set in marshal.go
+	Service string      `json:"service,omitempty"` // example: user-service
+	Code    string      `json:"code,omitempty"`    // example: 001
+	Message string      `json:"message"`           // human readable message
+	Fields  ErrorFields `json:"fields,omitempty"`  // additional fields, key-value pairs
+	// Violations lists typed field-level validation failures, in parallel
+	// with the free-form Fields above. Set via WithViolation/WithViolations
+	// and consumed in violations.go.
+	Violations []FieldViolation `json:"violations,omitempty"`
+	cause      error            // wrapped error
+	grpcCode   uint32           // used in grpc.go
+	traceID    string           // set in marshal.go
+
+	// Standard google.rpc error detail payloads, attached via the With*
+	// builders in details.go and round-tripped by GRPCStatus/FromGRPCStatus.
+	badRequest       *errdetails.BadRequest
+	retryInfo        *errdetails.RetryInfo
+	quotaFailure     *errdetails.QuotaFailure
+	resourceInfo     *errdetails.ResourceInfo
+	help             *errdetails.Help
+	localizedMessage *errdetails.LocalizedMessage
+
+	// Localization inputs, set via WithLocale/WithLocalizationKey and
+	// consumed in locale.go to render localizedMessage on demand.
+	locale      language.Tag
+	messageKey  string
+	messageArgs ErrorFields
 }
 
 // New creates a new AppError.
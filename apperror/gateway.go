@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/FurmanovVitaliy/grpc-api/gen/go/errors/errors"
@@ -20,6 +23,58 @@ type HTTPError struct {
 	Message     string            `json:"message"`
 	Fields      map[string]string `json:"fields,omitempty"`
 	TraceID     string            `json:"trace_id,omitempty"`
+	// RetryAfter mirrors the Retry-After header, in seconds, when the error
+	// is retryable.
+	RetryAfter int `json:"retry_after,omitempty"`
+	// Details mirrors the gRPC status's google.rpc error details, so REST
+	// clients get the same structured payload gRPC clients receive.
+	Details []ErrorDetailEntry `json:"details,omitempty"`
+	// Errors is populated instead of Service/ServiceCode when the gRPC
+	// status carries more than one pb.ErrorDetail, i.e. it originated from a
+	// MultiError; Message then holds the status's combined message.
+	Errors []AggregatedError `json:"errors,omitempty"`
+	// FieldViolations mirrors a BadRequest detail's field violations when
+	// the gRPC status code is codes.InvalidArgument.
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+}
+
+// AggregatedError is one constituent error rendered under HTTPError.Errors.
+type AggregatedError struct {
+	Service     string            `json:"service,omitempty"`
+	ServiceCode string            `json:"service_code,omitempty"`
+	Message     string            `json:"message"`
+	Fields      map[string]string `json:"fields,omitempty"`
+}
+
+// ErrorDetailEntry is one entry in HTTPError.Details, wrapping a single
+// google.rpc error detail with a stable type tag so clients can switch on it.
+type ErrorDetailEntry struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// ErrorDetailEntries converts the recognized google.rpc detail types found
+// in st into a stable, JSON-friendly slice for HTTPError.Details. Gateway
+// handlers outside this package use it to populate their own Details field.
+func ErrorDetailEntries(st *status.Status) []ErrorDetailEntry {
+	var entries []ErrorDetailEntry
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *errdetails.BadRequest:
+			entries = append(entries, ErrorDetailEntry{Type: "bad_request", Value: detail})
+		case *errdetails.RetryInfo:
+			entries = append(entries, ErrorDetailEntry{Type: "retry_info", Value: detail})
+		case *errdetails.QuotaFailure:
+			entries = append(entries, ErrorDetailEntry{Type: "quota_failure", Value: detail})
+		case *errdetails.ResourceInfo:
+			entries = append(entries, ErrorDetailEntry{Type: "resource_info", Value: detail})
+		case *errdetails.Help:
+			entries = append(entries, ErrorDetailEntry{Type: "help", Value: detail})
+		case *errdetails.LocalizedMessage:
+			entries = append(entries, ErrorDetailEntry{Type: "localized_message", Value: detail})
+		}
+	}
+	return entries
 }
 
 // GRPCAppErrorHandler is a custom error handler for grpc-gateway.
@@ -41,19 +96,84 @@ func GRPCAppErrorHandler(
 	}
 
 	// Check for custom error details in the gRPC status.
+	var errorDetails []*pb.ErrorDetail
+	var retryInfo *errdetails.RetryInfo
+	var localizedMessage *errdetails.LocalizedMessage
+	var badRequest *errdetails.BadRequest
 	for _, d := range st.Details() {
-		if detail, ok := d.(*pb.ErrorDetail); ok {
-			resp.Service = detail.GetService()
-			resp.ServiceCode = detail.GetCode()
-			// If there's a custom message in details, prefer it over the gRPC status message.
-			if detail.GetMessage() != "" {
-				resp.Message = detail.GetMessage()
+		switch detail := d.(type) {
+		case *pb.ErrorDetail:
+			errorDetails = append(errorDetails, detail)
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		case *errdetails.LocalizedMessage:
+			localizedMessage = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if st.Code() == codes.InvalidArgument && badRequest != nil {
+		resp.FieldViolations = FieldViolationsFromBadRequest(badRequest)
+	}
+
+	if len(errorDetails) > 1 {
+		resp.Errors = make([]AggregatedError, len(errorDetails))
+		for i, detail := range errorDetails {
+			resp.Errors[i] = AggregatedError{
+				Service:     detail.GetService(),
+				ServiceCode: detail.GetCode(),
+				Message:     detail.GetMessage(),
+				Fields:      detail.GetFields(),
+			}
+		}
+	} else if len(errorDetails) == 1 {
+		detail := errorDetails[0]
+		resp.Service = detail.GetService()
+		resp.ServiceCode = detail.GetCode()
+		// If there's a custom message in details, prefer it over the gRPC status message.
+		if detail.GetMessage() != "" {
+			resp.Message = detail.GetMessage()
+		}
+		resp.Fields = detail.GetFields()
+		// Prefer a registered (service, code) -> HTTP status mapping over
+		// the generic gRPC-code-to-HTTP-status translation.
+		if mapping, ok := DefaultCodeRegistry.Lookup(resp.Service, resp.ServiceCode); ok {
+			httpCode = mapping.HTTPStatus
+			resp.Code = httpCode
+		}
+	} else if resolved := Resolve(err); resolved != nil {
+		// err didn't originate from an AppError on the gRPC side (no
+		// pb.ErrorDetail made it into the status); fall back to sentinel
+		// classification so the response still carries a meaningful code.
+		httpCode = runtime.HTTPStatusFromCode(codes.Code(resolved.grpcCode))
+		resp.Code = httpCode
+		resp.Service = resolved.Service
+		resp.ServiceCode = resolved.Code
+		resp.Message = resolved.Message
+	}
+
+	resp.Details = ErrorDetailEntries(st)
+
+	// Prefer a LocalizedMessage detail baked in for the caller's locale;
+	// otherwise fall back to rendering one from resp.ServiceCode via the
+	// registered Translator, since the wire format carries no dedicated
+	// localization key.
+	if locale := FirstLocale(r.Header.Get("Accept-Language")); locale != "" {
+		switch {
+		case localizedMessage != nil && SameLocale(localizedMessage.GetLocale(), locale):
+			resp.Message = localizedMessage.GetMessage()
+		case resp.ServiceCode != "":
+			if msg, ok := Translate(locale, resp.ServiceCode, resp.Fields); ok {
+				resp.Message = msg
 			}
-			resp.Fields = detail.GetFields()
-			break
 		}
 	}
 
+	if seconds, ok := RetryAfterSeconds(st.Code(), resp.ServiceCode, retryInfo); ok {
+		resp.RetryAfter = seconds
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
 	// Extract trace ID from the context using OpenTelemetry.
 	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
 		resp.TraceID = span.TraceID().String()
@@ -1,8 +1,10 @@
 package apperror
 
 import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 
 	pb "github.com/FurmanovVitaliy/grpc-api/gen/go/errors/errors"
 )
@@ -18,56 +20,122 @@ func (e *AppError) WithGRPCCode(code codes.Code) *AppError {
 // GRPCStatus converts the AppError to a gRPC *status.Status.
 // It embeds the AppError's details into the status, allowing them to be
 // extracted by a gRPC client or gateway.
-// If no gRPC code was set, it defaults to codes.Unknown.
+// If no gRPC code was set, it consults DefaultCodeRegistry for a mapping
+// derived from Service and Code, and falls back to codes.Unknown.
 func (e *AppError) GRPCStatus() *status.Status {
 	code := codes.Code(e.grpcCode)
 	// An error should not have a status of OK (0).
-	// If no specific gRPC code was set, default to Unknown.
-	// This handles cases where an error is created via apperror.New()
-	// without a subsequent call to .WithGRPCCode().
+	// If no specific gRPC code was set, this handles cases where an error
+	// is created via apperror.New() without a subsequent call to
+	// .WithGRPCCode(): try the registry before defaulting to Unknown.
 	if code == codes.OK {
-		code = codes.Unknown
+		if mapping, ok := DefaultCodeRegistry.Lookup(e.Service, e.Code); ok {
+			code = mapping.GRPCCode
+		} else {
+			code = codes.Unknown
+		}
 	}
 
 	st := status.New(code, e.Message)
-	detail := &pb.ErrorDetail{
-		Service: e.Service,
-		Code:    e.Code,
-		Message: e.Message,
-		Fields:  e.Fields,
+	details := []protoadapt.MessageV1{
+		&pb.ErrorDetail{
+			Service: e.Service,
+			Code:    e.Code,
+			Message: e.Message,
+			Fields:  e.Fields,
+		},
+	}
+	badRequest := e.badRequest
+	if badRequest == nil {
+		// No explicit WithBadRequest: derive one from Violations, if any.
+		badRequest = badRequestFromViolations(e.Violations)
+	}
+	if badRequest != nil {
+		details = append(details, badRequest)
+	}
+	if e.retryInfo != nil {
+		details = append(details, e.retryInfo)
+	}
+	if e.quotaFailure != nil {
+		details = append(details, e.quotaFailure)
+	}
+	if e.resourceInfo != nil {
+		details = append(details, e.resourceInfo)
 	}
-	stWithDetail, err := st.WithDetails(detail)
+	if e.help != nil {
+		details = append(details, e.help)
+	}
+	localizedMessage := e.localizedMessage
+	if localizedMessage == nil {
+		// No explicit WithLocalizedMessage: render one from
+		// WithLocalizationKey/WithLocale via the registered Translator, if any.
+		_, localizedMessage = e.localize("")
+	}
+	if localizedMessage != nil {
+		details = append(details, localizedMessage)
+	}
+
+	stWithDetail, err := st.WithDetails(details...)
 	if err != nil {
 		return status.New(codes.Internal, "failed to marshal error")
 	}
 	return stWithDetail
 }
 
-// FromGRPCStatus creates an AppError from a gRPC *status.Status.
-// It attempts to extract the detailed error information from the status's details.
-// If no details are found, it creates a new AppError from the status's message and code.
-func FromGRPCStatus(st *status.Status) *AppError {
+// FromGRPCStatus creates an error from a gRPC *status.Status.
+// It attempts to extract the detailed error information from the status's
+// details. If no pb.ErrorDetail is found, it creates a new AppError from the
+// status's message and code. If more than one pb.ErrorDetail is found, it
+// reconstructs a *MultiError with one constituent error per detail instead.
+// Any standard google.rpc detail types (BadRequest, RetryInfo, QuotaFailure,
+// ResourceInfo, Help, LocalizedMessage) found alongside a single pb.ErrorDetail
+// are reattached and exposed via their typed accessors. A BadRequest detail
+// also rehydrates Violations, though its Rule and Value won't survive the
+// round trip.
+func FromGRPCStatus(st *status.Status) error {
 	if st == nil {
 		return nil
 	}
 
-	for _, d := range st.Details() {
-		if detail, ok := d.(*pb.ErrorDetail); ok {
-			return &AppError{
-				Service:  detail.GetService(),
-				Code:     detail.GetCode(),
-				Message:  detail.GetMessage(),
-				Fields:   detail.GetFields(),
-				grpcCode: uint32(st.Code()),
-			}
-		}
-	}
-
-	// Fallback for a standard gRPC error without custom details.
-	return &AppError{
+	var errorDetails []*pb.ErrorDetail
+	appErr := &AppError{
 		Service:  "unknown",
 		Code:     "00000",
 		Message:  st.Message(),
 		grpcCode: uint32(st.Code()),
 	}
+
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *pb.ErrorDetail:
+			errorDetails = append(errorDetails, detail)
+		case *errdetails.BadRequest:
+			appErr.badRequest = detail
+			appErr.Violations = FieldViolationsFromBadRequest(detail)
+		case *errdetails.RetryInfo:
+			appErr.retryInfo = detail
+		case *errdetails.QuotaFailure:
+			appErr.quotaFailure = detail
+		case *errdetails.ResourceInfo:
+			appErr.resourceInfo = detail
+		case *errdetails.Help:
+			appErr.help = detail
+		case *errdetails.LocalizedMessage:
+			appErr.localizedMessage = detail
+		}
+	}
+
+	if len(errorDetails) > 1 {
+		return multiErrorFromDetails(errorDetails)
+	}
+
+	if len(errorDetails) == 1 {
+		detail := errorDetails[0]
+		appErr.Service = detail.GetService()
+		appErr.Code = detail.GetCode()
+		appErr.Message = detail.GetMessage()
+		appErr.Fields = detail.GetFields()
+	}
+
+	return appErr
 }
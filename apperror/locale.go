@@ -0,0 +1,127 @@
+package apperror
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+)
+
+// localeMetadataKey is the gRPC metadata key carrying a caller's preferred
+// locale, the gRPC equivalent of the HTTP Accept-Language header.
+const localeMetadataKey = "accept-language"
+
+// Translator renders the message template identified by key, filled in
+// with args, in locale. It returns false if it has no translation for key.
+type Translator func(locale, key string, args map[string]string) (string, bool)
+
+// translator is the package-level hook consulted by ToJSON and GRPCStatus to
+// render an end-user-safe, localized message for an AppError that carries a
+// WithLocalizationKey, and by the gateway error handlers, via Translate,
+// keyed by the status's service_code.
+var translator Translator
+
+// SetTranslator installs the Translator consulted when rendering a
+// localized message. Pass nil to disable localization.
+func SetTranslator(t Translator) {
+	translator = t
+}
+
+// WithLocale sets the locale e's localized message should be rendered in,
+// taking precedence over whatever locale is found in a request context.
+// GRPCStatus has no context to consult, so server-side code that wants a
+// baked-in LocalizedMessage detail must set this explicitly.
+func (e *AppError) WithLocale(tag language.Tag) *AppError {
+	copyErr := *e
+	copyErr.locale = tag
+	return &copyErr
+}
+
+// WithLocalizationKey attaches a message template key and its arguments,
+// consulted by the registered Translator to render an end-user-safe
+// message. It leaves Message, used for logs, untouched.
+func (e *AppError) WithLocalizationKey(key string, args ErrorFields) *AppError {
+	copyErr := *e
+	copyErr.messageKey = key
+	copyErr.messageArgs = args
+	return &copyErr
+}
+
+// localeFromContext extracts the caller's preferred locale from incoming
+// gRPC metadata, keyed by localeMetadataKey.
+func localeFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(localeMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return FirstLocale(values[0])
+}
+
+// FirstLocale returns the first locale tag in a comma-separated
+// Accept-Language-style header or metadata value, ignoring any quality
+// value, e.g. "fr-FR;q=0.9, en-US" -> "fr-FR". Gateway handlers outside this
+// package use it directly on the HTTP Accept-Language header.
+func FirstLocale(header string) string {
+	first := strings.SplitN(header, ",", 2)[0]
+	first = strings.SplitN(first, ";", 2)[0]
+	return strings.TrimSpace(first)
+}
+
+// localize renders e's end-user-safe message in locale using the
+// registered Translator. locale is overridden by e.locale, set via
+// WithLocale, when present. It returns e.Message and a nil detail when
+// there's no translator, no WithLocalizationKey, no locale to render in, or
+// the translator has no entry for the key.
+func (e *AppError) localize(locale string) (string, *errdetails.LocalizedMessage) {
+	if e.locale != language.Und {
+		locale = e.locale.String()
+	}
+	if translator == nil || e.messageKey == "" || locale == "" {
+		return e.Message, nil
+	}
+	msg, ok := translator(locale, e.messageKey, e.messageArgs)
+	if !ok {
+		return e.Message, nil
+	}
+	return msg, &errdetails.LocalizedMessage{Locale: locale, Message: msg}
+}
+
+// Localized returns the text of e's LocalizedMessage detail if it was
+// recorded for tag, the locale FromGRPCStatus (or WithLocalizedMessage)
+// attached it for. It returns "" if e carries no LocalizedMessage detail or
+// it was recorded for a different locale.
+func (e *AppError) Localized(tag language.Tag) string {
+	if e.localizedMessage == nil || !SameLocale(e.localizedMessage.GetLocale(), tag.String()) {
+		return ""
+	}
+	return e.localizedMessage.GetMessage()
+}
+
+// SameLocale reports whether a and b denote the same language.Tag once
+// canonicalized, e.g. "en-US" and "en_US" match. Gateway handlers outside
+// this package use it to compare an Accept-Language header against a
+// LocalizedMessage detail's recorded locale.
+func SameLocale(a, b string) bool {
+	ta, errA := language.Parse(a)
+	tb, errB := language.Parse(b)
+	return errA == nil && errB == nil && ta == tb
+}
+
+// Translate renders the message template identified by key, filled in with
+// args, in locale, using the registered Translator. It returns false if no
+// Translator is installed or the Translator has no entry for key. Gateway
+// handlers outside this package use it as a fallback when a gRPC status
+// carries no LocalizedMessage detail matching the caller's locale, keying
+// the lookup off the service_code since that's all the wire format carries.
+func Translate(locale, key string, args ErrorFields) (string, bool) {
+	if translator == nil {
+		return "", false
+	}
+	return translator(locale, key, args)
+}
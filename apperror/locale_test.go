@@ -0,0 +1,133 @@
+package apperror
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestFirstLocale(t *testing.T) {
+	cases := map[string]string{
+		"fr-FR;q=0.9, en-US": "fr-FR",
+		"en-US":              "en-US",
+		" en-US ;q=0.8":      "en-US",
+		"":                   "",
+	}
+	for header, want := range cases {
+		if got := FirstLocale(header); got != want {
+			t.Errorf("FirstLocale(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestSameLocale(t *testing.T) {
+	if !SameLocale("en-US", "en_US") {
+		t.Errorf("SameLocale(en-US, en_US) = false, want true")
+	}
+	if SameLocale("en-US", "fr-FR") {
+		t.Errorf("SameLocale(en-US, fr-FR) = true, want false")
+	}
+	if SameLocale("not-a-locale!!", "en-US") {
+		t.Errorf("SameLocale() with an unparsable tag = true, want false")
+	}
+}
+
+func TestAppError_LocalizeWithTranslator(t *testing.T) {
+	defer SetTranslator(nil)
+	SetTranslator(func(locale, key string, args map[string]string) (string, bool) {
+		if locale == "fr-FR" && key == "greeting" {
+			return "bonjour " + args["name"], true
+		}
+		return "", false
+	})
+
+	err := New("svc", "001", "hello").WithLocalizationKey("greeting", ErrorFields{"name": "ada"})
+
+	msg, detail := err.localize("fr-FR")
+	if msg != "bonjour ada" {
+		t.Fatalf("localize() message = %q, want %q", msg, "bonjour ada")
+	}
+	if detail == nil || detail.GetLocale() != "fr-FR" || detail.GetMessage() != "bonjour ada" {
+		t.Fatalf("localize() detail = %+v, want fr-FR/\"bonjour ada\"", detail)
+	}
+}
+
+func TestAppError_LocalizeWithLocaleOverridesParam(t *testing.T) {
+	defer SetTranslator(nil)
+	SetTranslator(func(locale, key string, args map[string]string) (string, bool) {
+		return "[" + locale + "] " + key, true
+	})
+
+	err := New("svc", "001", "hello").
+		WithLocalizationKey("greeting", nil).
+		WithLocale(language.German)
+
+	msg, detail := err.localize("fr-FR")
+	if msg != "[de] greeting" {
+		t.Fatalf("localize() message = %q, want WithLocale(German) to win over the fr-FR param", msg)
+	}
+	if detail.GetLocale() != "de" {
+		t.Fatalf("localize() detail locale = %q, want %q", detail.GetLocale(), "de")
+	}
+}
+
+func TestAppError_LocalizeFallsBackToMessage(t *testing.T) {
+	defer SetTranslator(nil)
+
+	// No translator installed.
+	err := New("svc", "001", "hello").WithLocalizationKey("greeting", nil)
+	if msg, detail := err.localize("fr-FR"); msg != "hello" || detail != nil {
+		t.Fatalf("localize() with no translator = %q, %+v, want %q, nil", msg, detail, "hello")
+	}
+
+	// Translator installed but no WithLocalizationKey.
+	SetTranslator(func(locale, key string, args map[string]string) (string, bool) {
+		t.Fatalf("translator should not be consulted without a localization key")
+		return "", false
+	})
+	plain := New("svc", "001", "hello")
+	if msg, detail := plain.localize("fr-FR"); msg != "hello" || detail != nil {
+		t.Fatalf("localize() without a key = %q, %+v, want %q, nil", msg, detail, "hello")
+	}
+
+	// Translator has no entry for the key.
+	SetTranslator(func(locale, key string, args map[string]string) (string, bool) {
+		return "", false
+	})
+	missing := New("svc", "001", "hello").WithLocalizationKey("unknown", nil)
+	if msg, detail := missing.localize("fr-FR"); msg != "hello" || detail != nil {
+		t.Fatalf("localize() with a missing key = %q, %+v, want %q, nil", msg, detail, "hello")
+	}
+}
+
+func TestAppError_Localized(t *testing.T) {
+	err := New("svc", "001", "hello").WithLocalizedMessage("fr-FR", "bonjour")
+
+	if got := err.Localized(language.MustParse("fr-FR")); got != "bonjour" {
+		t.Fatalf("Localized(fr-FR) = %q, want %q", got, "bonjour")
+	}
+	if got := err.Localized(language.German); got != "" {
+		t.Fatalf("Localized(German) = %q, want \"\" for a non-matching locale", got)
+	}
+
+	bare := New("svc", "001", "hello")
+	if got := bare.Localized(language.MustParse("fr-FR")); got != "" {
+		t.Fatalf("Localized() with no LocalizedMessage = %q, want \"\"", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer SetTranslator(nil)
+
+	if _, ok := Translate("fr-FR", "greeting", nil); ok {
+		t.Fatalf("Translate() with no translator installed = true, want false")
+	}
+
+	SetTranslator(func(locale, key string, args map[string]string) (string, bool) {
+		return "bonjour", locale == "fr-FR" && key == "greeting"
+	})
+	msg, ok := Translate("fr-FR", "greeting", nil)
+	if !ok || msg != "bonjour" {
+		t.Fatalf("Translate() = %q, %v, want %q, true", msg, ok, "bonjour")
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 // ToJSON marshals an AppError to a JSON byte slice, suitable for structured logging.
-// It enriches the error with a trace_id from the context, if available.
+// It enriches the error with a trace_id from the context, if available, and,
+// if the caller's locale can be found in ctx and a Translator is installed,
+// renders Message as an end-user-safe, localized string.
 // The marshaling is done using a custom alias to avoid marshaling recursion.
 func ToJSON(ctx context.Context, err error) []byte {
 	var appErr *AppError
@@ -23,18 +26,33 @@ func ToJSON(ctx context.Context, err error) []byte {
 	// but none of its methods (including MarshalJSON).
 	type alias AppError
 
+	// appErr is the caller's own error, possibly shared with other goroutines
+	// or reused after this call; copy it before mutating so ToJSON never
+	// leaks the trace ID or a localized Message back into it.
+	cp := *appErr
+
 	// Enrich the error with the trace_id from the context before marshaling.
 	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
-		appErr.traceID = span.TraceID().String()
+		cp.traceID = span.TraceID().String()
+	}
+
+	var localizedMessage *errdetails.LocalizedMessage
+	if locale := localeFromContext(ctx); locale != "" {
+		if msg, detail := appErr.localize(locale); detail != nil {
+			cp.Message = msg
+			localizedMessage = detail
+		}
 	}
 
 	b, marshalErr := json.Marshal(&struct {
 		*alias
 
-		TraceID string `json:"trace_id,omitempty"`
+		TraceID          string                       `json:"trace_id,omitempty"`
+		LocalizedMessage *errdetails.LocalizedMessage `json:"localized_message,omitempty"`
 	}{
-		alias:   (*alias)(appErr),
-		TraceID: appErr.traceID,
+		alias:            (*alias)(&cp),
+		TraceID:          cp.traceID,
+		LocalizedMessage: localizedMessage,
 	})
 	if marshalErr != nil {
 		return []byte(`{"error":"failed to marshal app error: ` + marshalErr.Error() + `"}`)
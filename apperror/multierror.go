@@ -0,0 +1,184 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	pb "github.com/FurmanovVitaliy/grpc-api/gen/go/errors/errors"
+)
+
+// ErrorSeverity ranks gRPC codes so MultiError.GRPCStatus can pick the most
+// severe code among its constituents: a lower rank wins over a higher one.
+// Codes absent from the table are treated as the least severe. Callers may
+// add or override entries to declare their own severity ordering.
+var ErrorSeverity = map[codes.Code]int{
+	codes.DataLoss:           0,
+	codes.Internal:           1,
+	codes.Unknown:            2,
+	codes.Unavailable:        3,
+	codes.ResourceExhausted:  4,
+	codes.Aborted:            5,
+	codes.FailedPrecondition: 6,
+	codes.PermissionDenied:   7,
+	codes.Unauthenticated:    8,
+	codes.InvalidArgument:    9,
+	codes.AlreadyExists:      10,
+	codes.NotFound:           11,
+	codes.Canceled:           12,
+	codes.DeadlineExceeded:   13,
+}
+
+// severityRank returns code's rank in ErrorSeverity, treating codes absent
+// from the table as less severe than any entry in it.
+func severityRank(code codes.Code) int {
+	if rank, ok := ErrorSeverity[code]; ok {
+		return rank
+	}
+	worst := 0
+	for _, rank := range ErrorSeverity {
+		if rank > worst {
+			worst = rank
+		}
+	}
+	return worst + 1
+}
+
+// MultiError aggregates multiple errors into one, preserving each as its
+// own pb.ErrorDetail entry when transported over gRPC.
+type MultiError struct {
+	errs []error
+}
+
+// Join combines errs into a *MultiError, skipping any nil entries. If none
+// of the errors are non-nil, Join returns nil.
+func Join(errs ...error) *MultiError {
+	me := &MultiError{}
+	for _, err := range errs {
+		if err != nil {
+			me.errs = append(me.errs, err)
+		}
+	}
+	if len(me.errs) == 0 {
+		return nil
+	}
+	return me
+}
+
+// Error implements the error interface by concatenating every constituent
+// error's message.
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msg := fmt.Sprintf("%d errors occurred:", len(m.errs))
+	for _, err := range m.errs {
+		msg += "\n\t* " + err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the constituent errors, so errors.Is and errors.As can
+// inspect each of them per the Go 1.20+ multi-error convention.
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Is reports whether any constituent error matches target.
+func (m *MultiError) Is(target error) bool {
+	for _, err := range m.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the constituent errors in the order they were joined.
+func (m *MultiError) Errors() []error {
+	return m.errs
+}
+
+// multiUnwrapper is implemented by any multi-error, not just *MultiError
+// itself (e.g. the stdlib errors.Join result), that bundles more than one
+// error behind Unwrap() []error.
+type multiUnwrapper interface {
+	Unwrap() []error
+}
+
+// flattenErrors expands any constituent that is itself a *MultiError or
+// other Unwrap() []error bundle into its own leaves, so a MultiError nested
+// inside another MultiError (e.g. Join(Join(errA, errB), errC), which a
+// validator returning its own Join and a caller re-Joining it invites)
+// contributes all of its constituents instead of just the first one
+// errors.As happens to find.
+func flattenErrors(errs []error) []error {
+	var out []error
+	for _, err := range errs {
+		if u, ok := err.(multiUnwrapper); ok {
+			out = append(out, flattenErrors(u.Unwrap())...)
+			continue
+		}
+		out = append(out, err)
+	}
+	return out
+}
+
+// GRPCStatus converts the MultiError to a gRPC *status.Status. Each
+// constituent error, with any nested MultiError flattened first, is
+// attached as its own pb.ErrorDetail, and the overall code is the most
+// severe one among them per ErrorSeverity.
+func (m *MultiError) GRPCStatus() *status.Status {
+	bestCode := codes.Unknown
+	bestRank := -1 // no constituent seen yet; the first one always wins
+	errs := flattenErrors(m.errs)
+	details := make([]protoadapt.MessageV1, 0, len(errs))
+
+	for _, err := range errs {
+		var appErr *AppError
+		code := codes.Unknown
+		if errors.As(err, &appErr) {
+			code = codes.Code(appErr.grpcCode)
+			if code == codes.OK {
+				code = codes.Unknown
+			}
+			details = append(details, &pb.ErrorDetail{
+				Service: appErr.Service,
+				Code:    appErr.Code,
+				Message: appErr.Message,
+				Fields:  appErr.Fields,
+			})
+		} else {
+			details = append(details, &pb.ErrorDetail{Message: err.Error()})
+		}
+		if rank := severityRank(code); bestRank < 0 || rank < bestRank {
+			bestRank = rank
+			bestCode = code
+		}
+	}
+
+	st := status.New(bestCode, m.Error())
+	stWithDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return status.New(codes.Internal, "failed to marshal multi-error")
+	}
+	return stWithDetails
+}
+
+// multiErrorFromDetails reconstructs a MultiError from more than one
+// pb.ErrorDetail found in a gRPC status, as produced by MultiError.GRPCStatus.
+func multiErrorFromDetails(details []*pb.ErrorDetail) *MultiError {
+	errs := make([]error, len(details))
+	for i, d := range details {
+		errs[i] = &AppError{
+			Service: d.GetService(),
+			Code:    d.GetCode(),
+			Message: d.GetMessage(),
+			Fields:  d.GetFields(),
+		}
+	}
+	return &MultiError{errs: errs}
+}
@@ -0,0 +1,75 @@
+package apperror
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestJoin_SkipsNilAndReturnsNilWhenEmpty(t *testing.T) {
+	if got := Join(nil, nil); got != nil {
+		t.Fatalf("Join(nil, nil) = %v, want nil", got)
+	}
+
+	err := errors.New("boom")
+	me := Join(nil, err, nil)
+	if me == nil || len(me.Errors()) != 1 || me.Errors()[0] != err {
+		t.Fatalf("Join() = %+v, want a MultiError wrapping just %v", me, err)
+	}
+}
+
+func TestMultiError_GRPCStatusPicksMostSevere(t *testing.T) {
+	// DataLoss outranks Internal, which outranks the generic error's Unknown.
+	me := Join(
+		New("svc", "002", "internal failure").WithGRPCCode(codes.Internal),
+		New("svc", "001", "data loss").WithGRPCCode(codes.DataLoss),
+		errors.New("plain error"),
+	)
+
+	st := me.GRPCStatus()
+	if st.Code() != codes.DataLoss {
+		t.Fatalf("GRPCStatus().Code() = %v, want DataLoss", st.Code())
+	}
+	if len(st.Details()) != 3 {
+		t.Fatalf("GRPCStatus() attached %d details, want 3", len(st.Details()))
+	}
+}
+
+func TestMultiError_GRPCStatusFirstConstituentWinsTies(t *testing.T) {
+	me := Join(
+		New("svc", "001", "not found").WithGRPCCode(codes.NotFound),
+		New("svc", "002", "also not found").WithGRPCCode(codes.NotFound),
+	)
+
+	if got := me.GRPCStatus().Code(); got != codes.NotFound {
+		t.Fatalf("GRPCStatus().Code() = %v, want NotFound", got)
+	}
+}
+
+func TestMultiError_GRPCStatusFlattensNestedMultiError(t *testing.T) {
+	errA := New("svc", "001", "a").WithGRPCCode(codes.NotFound)
+	errB := New("svc", "002", "b").WithGRPCCode(codes.NotFound)
+	errC := New("svc", "003", "c").WithGRPCCode(codes.NotFound)
+
+	// A validator returning its own Join, re-Joined by a caller with its
+	// own error, should surface all three constituents, not just the two
+	// errors.As's single-error DFS happens to find first.
+	me := Join(Join(errA, errB), errC)
+
+	st := me.GRPCStatus()
+	if len(st.Details()) != 3 {
+		t.Fatalf("GRPCStatus() attached %d details, want 3 (nested MultiError must be flattened)", len(st.Details()))
+	}
+}
+
+func TestMultiError_Is(t *testing.T) {
+	me := Join(ErrNotFound, ErrInternal)
+
+	if !errors.Is(me, ErrInternal) {
+		t.Fatalf("errors.Is(me, ErrInternal) = false, want true")
+	}
+	if errors.Is(me, ErrUnavailable) {
+		t.Fatalf("errors.Is(me, ErrUnavailable) = true, want false")
+	}
+}
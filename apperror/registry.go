@@ -0,0 +1,151 @@
+package apperror
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeMapping pairs a gRPC code with the HTTP status it should map to.
+type CodeMapping struct {
+	GRPCCode   codes.Code
+	HTTPStatus int
+}
+
+// CodeRegistry is a concurrency-safe lookup from (service, code) to a
+// CodeMapping. GRPCStatus consults it whenever an AppError has no explicit
+// gRPC code, and the gateway handler consults it before falling back to
+// runtime.HTTPStatusFromCode. A lazily populated cache keeps hot paths from
+// repeating the pattern-matching walk.
+type CodeRegistry struct {
+	mu       sync.RWMutex
+	patterns map[string]CodeMapping // keyed by "service:pattern"
+	cache    sync.Map               // "service:code" -> CodeMapping
+}
+
+// NewCodeRegistry returns an empty CodeRegistry.
+func NewCodeRegistry() *CodeRegistry {
+	return &CodeRegistry{patterns: make(map[string]CodeMapping)}
+}
+
+// DefaultCodeRegistry is the registry consulted by GRPCStatus and the
+// gateway error handlers. Call RegisterCodes on it to declare a service's
+// whole error taxonomy in one place, or replace it wholesale for tests.
+var DefaultCodeRegistry = NewCodeRegistry()
+
+// RegisterCodes merges mappings into the registry. Each key has the form
+// "service:pattern", where pattern is an exact code (e.g. "US-404"), a
+// prefix glob ("US-*"), or "*" to match any code for that service.
+func (r *CodeRegistry) RegisterCodes(mappings map[string]CodeMapping) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range mappings {
+		r.patterns[k] = v
+	}
+	r.cache.Range(func(k, _ any) bool {
+		r.cache.Delete(k)
+		return true
+	})
+}
+
+// Lookup resolves (service, code) to a CodeMapping. It tries, in order: an
+// exact "service:code" pattern, a "service:prefix*" glob, "service:*", and
+// finally a decoder that treats the numeric portion of code as an HTTP
+// status hint (e.g. "US-404" -> 404, DB-500 -> 500). Results are cached per
+// (service, code).
+func (r *CodeRegistry) Lookup(service, code string) (CodeMapping, bool) {
+	cacheKey := service + ":" + code
+	if v, ok := r.cache.Load(cacheKey); ok {
+		entry := v.(cacheEntry)
+		return entry.mapping, entry.ok
+	}
+
+	// Hold the read lock across the store too, so a concurrent RegisterCodes
+	// can't clear the cache between computing a result and caching it.
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	mapping, ok := r.lookup(service, code)
+	r.cache.Store(cacheKey, cacheEntry{mapping: mapping, ok: ok})
+	return mapping, ok
+}
+
+type cacheEntry struct {
+	mapping CodeMapping
+	ok      bool
+}
+
+// lookup does the uncached resolution; r.mu must be held for reading.
+func (r *CodeRegistry) lookup(service, code string) (CodeMapping, bool) {
+	if m, ok := r.patterns[service+":"+code]; ok {
+		return m, true
+	}
+
+	// Walk every glob pattern registered for this service and keep the one
+	// with the longest matching prefix, so e.g. "US-4*" wins over "US-*" for
+	// code "US-404" regardless of map iteration order.
+	var best CodeMapping
+	var bestLen = -1
+	for pattern, m := range r.patterns {
+		glob, ok := strings.CutPrefix(pattern, service+":")
+		if !ok {
+			continue
+		}
+		prefix, ok := strings.CutSuffix(glob, "*")
+		if !ok {
+			continue
+		}
+		if !strings.HasPrefix(code, prefix) {
+			continue
+		}
+		if len(prefix) > bestLen {
+			bestLen = len(prefix)
+			best = m
+		}
+	}
+	if bestLen >= 0 {
+		return best, true
+	}
+
+	if m, ok := decodeHTTPHint(code); ok {
+		return m, true
+	}
+	return CodeMapping{}, false
+}
+
+// decodeHTTPHint treats the numeric suffix of code (after the last '-') as
+// an HTTP status hint, e.g. "US-404" -> 404, and maps well-known HTTP
+// statuses back to a gRPC code.
+func decodeHTTPHint(code string) (CodeMapping, bool) {
+	idx := strings.LastIndex(code, "-")
+	if idx < 0 || idx == len(code)-1 {
+		return CodeMapping{}, false
+	}
+	httpStatus, err := strconv.Atoi(code[idx+1:])
+	if err != nil {
+		return CodeMapping{}, false
+	}
+	grpcCode, ok := httpToGRPCCode[httpStatus]
+	if !ok {
+		return CodeMapping{}, false
+	}
+	return CodeMapping{GRPCCode: grpcCode, HTTPStatus: httpStatus}, true
+}
+
+// httpToGRPCCode maps common HTTP statuses to their closest gRPC code,
+// mirroring the reverse of runtime.HTTPStatusFromCode.
+var httpToGRPCCode = map[int]codes.Code{
+	400: codes.InvalidArgument,
+	401: codes.Unauthenticated,
+	403: codes.PermissionDenied,
+	404: codes.NotFound,
+	409: codes.AlreadyExists,
+	412: codes.FailedPrecondition,
+	429: codes.ResourceExhausted,
+	499: codes.Canceled,
+	500: codes.Internal,
+	501: codes.Unimplemented,
+	503: codes.Unavailable,
+	504: codes.DeadlineExceeded,
+}
@@ -0,0 +1,85 @@
+package apperror
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodeRegistry_LookupExactBeatsGlob(t *testing.T) {
+	r := NewCodeRegistry()
+	r.RegisterCodes(map[string]CodeMapping{
+		"US:*":      {GRPCCode: codes.Internal, HTTPStatus: 500},
+		"US:US-ABC": {GRPCCode: codes.AlreadyExists, HTTPStatus: 409},
+	})
+
+	m, ok := r.Lookup("US", "US-ABC")
+	if !ok || m.GRPCCode != codes.AlreadyExists || m.HTTPStatus != 409 {
+		t.Fatalf("Lookup() = %+v, %v; want the exact-match AlreadyExists/409", m, ok)
+	}
+}
+
+func TestCodeRegistry_LookupLongestGlobWins(t *testing.T) {
+	r := NewCodeRegistry()
+	r.RegisterCodes(map[string]CodeMapping{
+		"US:US-*":  {GRPCCode: codes.Internal, HTTPStatus: 500},
+		"US:US-A*": {GRPCCode: codes.AlreadyExists, HTTPStatus: 409},
+	})
+
+	m, ok := r.Lookup("US", "US-ABC")
+	if !ok || m.GRPCCode != codes.AlreadyExists || m.HTTPStatus != 409 {
+		t.Fatalf("Lookup() = %+v, %v; want AlreadyExists/409 from the longer prefix", m, ok)
+	}
+}
+
+func TestCodeRegistry_LookupFallsBackToHTTPHint(t *testing.T) {
+	r := NewCodeRegistry()
+
+	m, ok := r.Lookup("DB", "DB-500")
+	if !ok || m.GRPCCode != codes.Internal || m.HTTPStatus != 500 {
+		t.Fatalf("Lookup() = %+v, %v; want the decoded Internal/500 hint", m, ok)
+	}
+
+	if _, ok := r.Lookup("DB", "DB-NOPE"); ok {
+		t.Fatalf("Lookup() matched a code with no numeric suffix and no registered pattern")
+	}
+}
+
+func TestCodeRegistry_LookupCachesResult(t *testing.T) {
+	r := NewCodeRegistry()
+	r.RegisterCodes(map[string]CodeMapping{
+		"US:US-ABC": {GRPCCode: codes.AlreadyExists, HTTPStatus: 409},
+	})
+
+	if _, ok := r.Lookup("US", "US-ABC"); !ok {
+		t.Fatalf("Lookup() miss before warming the cache")
+	}
+
+	// Remove the pattern directly (bypassing RegisterCodes, which would
+	// invalidate the cache) to prove a subsequent Lookup still serves the
+	// cached result instead of recomputing and missing.
+	r.mu.Lock()
+	delete(r.patterns, "US:US-ABC")
+	r.mu.Unlock()
+
+	m, ok := r.Lookup("US", "US-ABC")
+	if !ok || m.GRPCCode != codes.AlreadyExists {
+		t.Fatalf("Lookup() = %+v, %v; want the cached AlreadyExists mapping", m, ok)
+	}
+}
+
+func TestCodeRegistry_RegisterCodesInvalidatesCache(t *testing.T) {
+	r := NewCodeRegistry()
+	if _, ok := r.Lookup("US", "US-ABC"); ok {
+		t.Fatalf("Lookup() unexpectedly matched before any pattern was registered")
+	}
+
+	r.RegisterCodes(map[string]CodeMapping{
+		"US:US-ABC": {GRPCCode: codes.AlreadyExists, HTTPStatus: 409},
+	})
+
+	m, ok := r.Lookup("US", "US-ABC")
+	if !ok || m.GRPCCode != codes.AlreadyExists {
+		t.Fatalf("Lookup() = %+v, %v; want the newly registered AlreadyExists mapping", m, ok)
+	}
+}
@@ -0,0 +1,57 @@
+package apperror
+
+import (
+	"math"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// RetryPolicyKey identifies a (gRPC code, service_code) pair in RetryPolicy.
+// ServiceCode may be left empty to match any service_code for that code.
+type RetryPolicyKey struct {
+	Code        codes.Code
+	ServiceCode string
+}
+
+// RetryPolicy maps a (code, service_code) pair to the default retry delay
+// used when an error is retryable but doesn't carry an explicit RetryInfo
+// detail. Callers may add or override entries to declare their own policy;
+// an entry with an empty ServiceCode acts as the default for that code.
+var RetryPolicy = map[RetryPolicyKey]time.Duration{
+	{Code: codes.Unavailable}:       1 * time.Second,
+	{Code: codes.ResourceExhausted}: 5 * time.Second,
+	{Code: codes.Aborted}:           500 * time.Millisecond,
+}
+
+// RetryAfterSeconds computes the Retry-After value (in whole seconds, per
+// RFC 7231) for a gRPC code and service_code. It prefers the explicit
+// RetryInfo detail when present, and otherwise falls back to RetryPolicy,
+// trying an exact (code, service_code) match before a code-only default.
+// Whether a code is "retryable" is derived entirely from RetryPolicy having
+// a matching entry, so callers can make any code retryable - or make one of
+// the defaults non-retryable - just by editing the map.
+// The second return value is false when the error isn't retryable and no
+// policy applies.
+func RetryAfterSeconds(code codes.Code, serviceCode string, retryInfo *errdetails.RetryInfo) (int, bool) {
+	var delay time.Duration
+	switch {
+	case retryInfo != nil:
+		delay = retryInfo.GetRetryDelay().AsDuration()
+	default:
+		if d, ok := RetryPolicy[RetryPolicyKey{Code: code, ServiceCode: serviceCode}]; ok {
+			delay = d
+		} else if d, ok := RetryPolicy[RetryPolicyKey{Code: code}]; ok {
+			delay = d
+		} else {
+			return 0, false
+		}
+	}
+
+	seconds := int(math.Ceil(delay.Seconds()))
+	if seconds < 0 {
+		seconds = 0
+	}
+	return seconds, true
+}
@@ -0,0 +1,53 @@
+package apperror
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRetryAfterSeconds_PrefersExplicitRetryInfo(t *testing.T) {
+	info := &errdetails.RetryInfo{RetryDelay: durationpb.New(3 * time.Second)}
+	seconds, ok := RetryAfterSeconds(codes.OK, "", info)
+	if !ok || seconds != 3 {
+		t.Fatalf("RetryAfterSeconds() = %d, %v, want 3, true", seconds, ok)
+	}
+}
+
+func TestRetryAfterSeconds_FallsBackToPolicyDefault(t *testing.T) {
+	seconds, ok := RetryAfterSeconds(codes.Unavailable, "", nil)
+	if !ok || seconds != 1 {
+		t.Fatalf("RetryAfterSeconds() = %d, %v, want 1, true", seconds, ok)
+	}
+}
+
+func TestRetryAfterSeconds_ServiceCodeOverridesDefault(t *testing.T) {
+	key := RetryPolicyKey{Code: codes.Unavailable, ServiceCode: "payments"}
+	RetryPolicy[key] = 7 * time.Second
+	defer delete(RetryPolicy, key)
+
+	seconds, ok := RetryAfterSeconds(codes.Unavailable, "payments", nil)
+	if !ok || seconds != 7 {
+		t.Fatalf("RetryAfterSeconds() = %d, %v, want 7, true", seconds, ok)
+	}
+}
+
+func TestRetryAfterSeconds_CustomCodeBecomesRetryableViaPolicy(t *testing.T) {
+	key := RetryPolicyKey{Code: codes.DeadlineExceeded}
+	RetryPolicy[key] = 2 * time.Second
+	defer delete(RetryPolicy, key)
+
+	seconds, ok := RetryAfterSeconds(codes.DeadlineExceeded, "", nil)
+	if !ok || seconds != 2 {
+		t.Fatalf("RetryAfterSeconds() = %d, %v, want 2, true", seconds, ok)
+	}
+}
+
+func TestRetryAfterSeconds_NotRetryableWithoutPolicy(t *testing.T) {
+	if _, ok := RetryAfterSeconds(codes.InvalidArgument, "", nil); ok {
+		t.Fatalf("RetryAfterSeconds(InvalidArgument) = _, true; want false")
+	}
+}
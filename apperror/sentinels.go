@@ -0,0 +1,80 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Sentinel AppErrors, each pre-bound to a canonical gRPC code. Third-party
+// error types can opt into classification by implementing Is(error) bool
+// and recognizing these values, then Resolve maps them back to a canonical
+// AppError without the caller needing to unwrap all the way to the leaf.
+var (
+	ErrNotFound           = New("", "not_found", "not found").WithGRPCCode(codes.NotFound)
+	ErrAlreadyExists      = New("", "already_exists", "already exists").WithGRPCCode(codes.AlreadyExists)
+	ErrPermissionDenied   = New("", "permission_denied", "permission denied").WithGRPCCode(codes.PermissionDenied)
+	ErrUnauthenticated    = New("", "unauthenticated", "unauthenticated").WithGRPCCode(codes.Unauthenticated)
+	ErrInvalidArgument    = New("", "invalid_argument", "invalid argument").WithGRPCCode(codes.InvalidArgument)
+	ErrFailedPrecondition = New("", "failed_precondition", "failed precondition").WithGRPCCode(codes.FailedPrecondition)
+	ErrResourceExhausted  = New("", "resource_exhausted", "resource exhausted").WithGRPCCode(codes.ResourceExhausted)
+	ErrAborted            = New("", "aborted", "aborted").WithGRPCCode(codes.Aborted)
+	ErrUnavailable        = New("", "unavailable", "unavailable").WithGRPCCode(codes.Unavailable)
+	ErrInternal           = New("", "internal", "internal error").WithGRPCCode(codes.Internal)
+	ErrDataLoss           = New("", "data_loss", "data loss").WithGRPCCode(codes.DataLoss)
+	ErrDeadlineExceeded   = New("", "deadline_exceeded", "deadline exceeded").WithGRPCCode(codes.DeadlineExceeded)
+	ErrCanceled           = New("", "canceled", "canceled").WithGRPCCode(codes.Canceled)
+	ErrNotImplemented     = New("", "not_implemented", "not implemented").WithGRPCCode(codes.Unimplemented)
+)
+
+// sentinels lists every sentinel AppError, in the order Resolve tests them.
+var sentinels = []*AppError{
+	ErrNotFound, ErrAlreadyExists, ErrPermissionDenied, ErrUnauthenticated,
+	ErrInvalidArgument, ErrFailedPrecondition, ErrResourceExhausted, ErrAborted,
+	ErrUnavailable, ErrInternal, ErrDataLoss, ErrDeadlineExceeded, ErrCanceled,
+	ErrNotImplemented,
+}
+
+// isChecker is implemented by errors that can report equivalence with
+// another error via a custom Is method, independent of Unwrap.
+type isChecker interface {
+	Is(error) bool
+}
+
+// Resolve walks err's wrapper chain looking for a node that implements
+// Is(error) bool, and tests it against every sentinel, returning the first
+// match. This lets downstream code map arbitrary third-party errors onto a
+// canonical gRPC/HTTP code without having to unwrap all the way to the leaf
+// cause, the technique containerd/errdefs calls Resolve.
+// *AppError nodes are compared by identity directly (via their own Is),
+// since they always implement Unwrap but should still be classifiable -
+// e.g. Resolve(ErrNotFound) or Resolve(fmt.Errorf("lookup: %w", ErrNotFound))
+// must both resolve. context.DeadlineExceeded and context.Canceled are
+// recognized directly. It returns nil if nothing matches.
+func Resolve(err error) *AppError {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrDeadlineExceeded
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrCanceled
+	}
+
+	for node := err; node != nil; node = errors.Unwrap(node) {
+		checker, ok := node.(isChecker)
+		if !ok {
+			continue
+		}
+		for _, s := range sentinels {
+			if checker.Is(s) {
+				return s
+			}
+		}
+	}
+
+	return nil
+}
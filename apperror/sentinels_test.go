@@ -0,0 +1,55 @@
+package apperror
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestResolve_MatchesSentinelDirectly(t *testing.T) {
+	if got := Resolve(ErrNotFound); got != ErrNotFound {
+		t.Fatalf("Resolve(ErrNotFound) = %v, want ErrNotFound", got)
+	}
+}
+
+func TestResolve_MatchesSentinelWrappedWithFmtErrorf(t *testing.T) {
+	err := fmt.Errorf("lookup failed: %w", ErrNotFound)
+	if got := Resolve(err); got != ErrNotFound {
+		t.Fatalf("Resolve(%%w-wrapped ErrNotFound) = %v, want ErrNotFound", got)
+	}
+}
+
+func TestResolve_MatchesThirdPartyIsImplementation(t *testing.T) {
+	third := thirdPartyErr{}
+	if got := Resolve(third); got != ErrAlreadyExists {
+		t.Fatalf("Resolve(thirdPartyErr) = %v, want ErrAlreadyExists", got)
+	}
+}
+
+func TestResolve_RecognizesContextSentinelsDirectly(t *testing.T) {
+	if got := Resolve(context.DeadlineExceeded); got != ErrDeadlineExceeded {
+		t.Fatalf("Resolve(context.DeadlineExceeded) = %v, want ErrDeadlineExceeded", got)
+	}
+	if got := Resolve(fmt.Errorf("op: %w", context.Canceled)); got != ErrCanceled {
+		t.Fatalf("Resolve(wrapped context.Canceled) = %v, want ErrCanceled", got)
+	}
+}
+
+func TestResolve_ReturnsNilWhenNothingMatches(t *testing.T) {
+	if got := Resolve(fmt.Errorf("plain error")); got != nil {
+		t.Fatalf("Resolve(plain error) = %v, want nil", got)
+	}
+	if got := Resolve(nil); got != nil {
+		t.Fatalf("Resolve(nil) = %v, want nil", got)
+	}
+}
+
+// thirdPartyErr mimics a third-party error type that opts into
+// classification via Is without being an *AppError itself.
+type thirdPartyErr struct{}
+
+func (thirdPartyErr) Error() string { return "third party: already exists" }
+
+func (thirdPartyErr) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
@@ -0,0 +1,52 @@
+package apperror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// WithViolation appends a single typed field-level violation to
+// Violations, built from field, the validation rule that failed, and a
+// human-readable description of the failure.
+func (e *AppError) WithViolation(field, rule, desc string) *AppError {
+	return e.WithViolations(FieldViolation{Field: field, Rule: rule, Description: desc})
+}
+
+// WithViolations appends violations to the error's Violations list. It
+// creates a new AppError to maintain immutability.
+func (e *AppError) WithViolations(violations ...FieldViolation) *AppError {
+	copyErr := *e
+	copyErr.Violations = append(append([]FieldViolation{}, e.Violations...), violations...)
+	return &copyErr
+}
+
+// badRequestFromViolations converts Violations into an errdetails.BadRequest,
+// one FieldViolation per entry. Rule and Value have no equivalent in the
+// google.rpc.BadRequest proto and are dropped.
+func badRequestFromViolations(violations []FieldViolation) *errdetails.BadRequest {
+	if len(violations) == 0 {
+		return nil
+	}
+	fv := make([]*errdetails.BadRequest_FieldViolation, len(violations))
+	for i, v := range violations {
+		fv[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+	return &errdetails.BadRequest{FieldViolations: fv}
+}
+
+// FieldViolationsFromBadRequest converts a google.rpc.BadRequest detail
+// back into Violations. Rule and Value weren't transmitted, so they come
+// back empty. Gateway handlers outside this package use it to surface
+// HTTPError.FieldViolations for codes.InvalidArgument responses.
+func FieldViolationsFromBadRequest(br *errdetails.BadRequest) []FieldViolation {
+	if br == nil || len(br.GetFieldViolations()) == 0 {
+		return nil
+	}
+	violations := make([]FieldViolation, len(br.GetFieldViolations()))
+	for i, v := range br.GetFieldViolations() {
+		violations[i] = FieldViolation{Field: v.GetField(), Description: v.GetDescription()}
+	}
+	return violations
+}
@@ -0,0 +1,81 @@
+package apperror
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestAppError_WithViolationAppends(t *testing.T) {
+	err := New("svc", "001", "invalid").
+		WithViolation("email", "required", "must be set").
+		WithViolation("age", "min", "must be at least 18")
+
+	if len(err.Violations) != 2 {
+		t.Fatalf("Violations = %+v, want 2 entries", err.Violations)
+	}
+	if err.Violations[0].Field != "email" || err.Violations[1].Field != "age" {
+		t.Fatalf("Violations = %+v, want email then age in call order", err.Violations)
+	}
+}
+
+func TestAppError_WithViolationsIsImmutable(t *testing.T) {
+	base := New("svc", "001", "invalid").WithViolation("email", "required", "must be set")
+	extended := base.WithViolation("age", "min", "must be at least 18")
+
+	if len(base.Violations) != 1 {
+		t.Fatalf("base.Violations = %+v, want the original WithViolation call left untouched", base.Violations)
+	}
+	if len(extended.Violations) != 2 {
+		t.Fatalf("extended.Violations = %+v, want 2 entries", extended.Violations)
+	}
+}
+
+func TestBadRequestFromViolations(t *testing.T) {
+	if got := badRequestFromViolations(nil); got != nil {
+		t.Fatalf("badRequestFromViolations(nil) = %+v, want nil", got)
+	}
+
+	br := badRequestFromViolations([]FieldViolation{
+		{Field: "email", Rule: "required", Description: "must be set", Value: "ignored"},
+	})
+	if br == nil || len(br.GetFieldViolations()) != 1 {
+		t.Fatalf("badRequestFromViolations() = %+v, want one field violation", br)
+	}
+	fv := br.GetFieldViolations()[0]
+	if fv.GetField() != "email" || fv.GetDescription() != "must be set" {
+		t.Fatalf("field violation = %+v, want field=email description=%q", fv, "must be set")
+	}
+}
+
+func TestFieldViolationsFromBadRequest(t *testing.T) {
+	if got := FieldViolationsFromBadRequest(nil); got != nil {
+		t.Fatalf("FieldViolationsFromBadRequest(nil) = %+v, want nil", got)
+	}
+	if got := FieldViolationsFromBadRequest(&errdetails.BadRequest{}); got != nil {
+		t.Fatalf("FieldViolationsFromBadRequest(empty) = %+v, want nil", got)
+	}
+
+	br := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "email", Description: "must be set"},
+	}}
+	violations := FieldViolationsFromBadRequest(br)
+	if len(violations) != 1 || violations[0].Field != "email" || violations[0].Description != "must be set" {
+		t.Fatalf("FieldViolationsFromBadRequest() = %+v, want one violation for \"email\"", violations)
+	}
+	// Rule and Value have no equivalent in the proto and don't survive the round trip.
+	if violations[0].Rule != "" || violations[0].Value != "" {
+		t.Fatalf("FieldViolationsFromBadRequest() = %+v, want Rule and Value left empty", violations)
+	}
+}
+
+func TestAppError_GRPCStatusDerivesBadRequestFromViolationsWhenUnset(t *testing.T) {
+	err := New("svc", "001", "invalid").WithViolation("email", "required", "must be set")
+
+	st := err.GRPCStatus()
+	got := FromGRPCStatus(st).(*AppError)
+
+	if len(got.Violations) != 1 || got.Violations[0].Field != "email" {
+		t.Fatalf("Violations = %+v, want one violation derived from WithViolation", got.Violations)
+	}
+}
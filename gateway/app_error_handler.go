@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/FurmanovVitaliy/go-structured-errors/apperror"
 	pb "github.com/FurmanovVitaliy/grpc-api/gen/go/errors/errors"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,6 +21,25 @@ type HTTPError struct {
 	ServiceCode string            `json:"service_code,omitempty"`
 	Message     string            `json:"message"`
 	Fields      map[string]string `json:"fields,omitempty"`
+	RetryAfter  int               `json:"retry_after,omitempty"`
+	// Details mirrors the gRPC status's google.rpc error details, so REST
+	// clients get the same structured payload gRPC clients receive.
+	Details []apperror.ErrorDetailEntry `json:"details,omitempty"`
+	// Errors is populated instead of Service/ServiceCode when the gRPC
+	// status carries more than one pb.ErrorDetail, i.e. it originated from an
+	// apperror.MultiError; Message then holds the status's combined message.
+	Errors []AggregatedError `json:"errors,omitempty"`
+	// FieldViolations mirrors a BadRequest detail's field violations when
+	// the gRPC status code is codes.InvalidArgument.
+	FieldViolations []apperror.FieldViolation `json:"field_violations,omitempty"`
+}
+
+// AggregatedError is one constituent error rendered under HTTPError.Errors.
+type AggregatedError struct {
+	Service     string            `json:"service,omitempty"`
+	ServiceCode string            `json:"service_code,omitempty"`
+	Message     string            `json:"message"`
+	Fields      map[string]string `json:"fields,omitempty"`
 }
 
 // / GRPCAppErrorHandler - handler for custom error handling
@@ -37,22 +59,34 @@ func GRPCAppErrorHandler(
 
 	httpCode := runtime.HTTPStatusFromCode(st.Code())
 
-	var errorDetail *pb.ErrorDetail
+	var errorDetails []*pb.ErrorDetail
+	var retryInfo *errdetails.RetryInfo
+	var localizedMessage *errdetails.LocalizedMessage
+	var badRequest *errdetails.BadRequest
 	for _, detail := range st.Details() {
-		if d, ok := detail.(*pb.ErrorDetail); ok {
-			errorDetail = d
-			break
+		switch d := detail.(type) {
+		case *pb.ErrorDetail:
+			errorDetails = append(errorDetails, d)
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		case *errdetails.LocalizedMessage:
+			localizedMessage = d
+		case *errdetails.BadRequest:
+			badRequest = d
 		}
 	}
 
-	if st.Code() == codes.Unavailable && errorDetail == nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusServiceUnavailable)
-
+	if st.Code() == codes.Unavailable && len(errorDetails) == 0 {
 		resp := HTTPError{
 			Code:    http.StatusServiceUnavailable,
 			Message: "Service unavailable. Please try again later.",
 		}
+		if seconds, ok := apperror.RetryAfterSeconds(st.Code(), "", retryInfo); ok {
+			resp.RetryAfter = seconds
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
 		_ = json.NewEncoder(w).Encode(resp)
 		return
 	}
@@ -63,10 +97,60 @@ func GRPCAppErrorHandler(
 		Message: st.Message(),
 	}
 
-	if errorDetail != nil {
-		resp.Service = errorDetail.Service
-		resp.ServiceCode = errorDetail.Code
-		resp.Fields = errorDetail.Fields
+	if st.Code() == codes.InvalidArgument && badRequest != nil {
+		resp.FieldViolations = apperror.FieldViolationsFromBadRequest(badRequest)
+	}
+
+	resp.Details = apperror.ErrorDetailEntries(st)
+
+	if len(errorDetails) > 1 {
+		resp.Errors = make([]AggregatedError, len(errorDetails))
+		for i, d := range errorDetails {
+			resp.Errors[i] = AggregatedError{
+				Service:     d.Service,
+				ServiceCode: d.Code,
+				Message:     d.Message,
+				Fields:      d.Fields,
+			}
+		}
+	} else if len(errorDetails) == 1 {
+		resp.Service = errorDetails[0].Service
+		resp.ServiceCode = errorDetails[0].Code
+		resp.Fields = errorDetails[0].Fields
+		// Prefer a registered (service, code) -> HTTP status mapping over
+		// the generic gRPC-code-to-HTTP-status translation.
+		if mapping, ok := apperror.DefaultCodeRegistry.Lookup(resp.Service, resp.ServiceCode); ok {
+			httpCode = mapping.HTTPStatus
+			resp.Code = httpCode
+		}
+	} else if resolved := apperror.Resolve(err); resolved != nil {
+		// err didn't originate from an AppError (no pb.ErrorDetail made it
+		// into the status); fall back to sentinel classification.
+		httpCode = runtime.HTTPStatusFromCode(resolved.GRPCStatus().Code())
+		resp.Code = httpCode
+		resp.Service = resolved.Service
+		resp.ServiceCode = resolved.Code
+		resp.Message = resolved.Message
+	}
+
+	// Prefer a LocalizedMessage detail baked in for the caller's locale;
+	// otherwise fall back to rendering one from resp.ServiceCode via the
+	// registered Translator, since the wire format carries no dedicated
+	// localization key.
+	if locale := apperror.FirstLocale(r.Header.Get("Accept-Language")); locale != "" {
+		switch {
+		case localizedMessage != nil && apperror.SameLocale(localizedMessage.GetLocale(), locale):
+			resp.Message = localizedMessage.GetMessage()
+		case resp.ServiceCode != "":
+			if msg, ok := apperror.Translate(locale, resp.ServiceCode, resp.Fields); ok {
+				resp.Message = msg
+			}
+		}
+	}
+
+	if seconds, ok := apperror.RetryAfterSeconds(st.Code(), resp.ServiceCode, retryInfo); ok {
+		resp.RetryAfter = seconds
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
 	}
 
 	w.Header().Set("Content-Type", "application/json")